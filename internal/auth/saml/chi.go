@@ -0,0 +1,24 @@
+package saml
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Mount registers the metadata, login, ACS, and SLO endpoints on r under
+// "/saml/*" and returns a RequireAuth middleware that protects routes
+// mounted elsewhere on r.
+func Mount(r chi.Router, opts Options) (requireAuth func(http.Handler) http.Handler, err error) {
+	sp, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Get("/saml/metadata", sp.metadataHandler)
+	r.Get("/saml/login", sp.loginHandler)
+	r.Post("/saml/acs", sp.acsHandler)
+	r.Get("/saml/slo", sp.sloHandler)
+
+	return sp.requireAuth, nil
+}
@@ -0,0 +1,24 @@
+package saml
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+)
+
+type sessionContextKey struct{}
+
+func withSession(ctx context.Context, sess *Session) context.Context {
+	return context.WithValue(ctx, sessionContextKey{}, sess)
+}
+
+// SessionFromContext returns the Session attached by RequireAuth, or nil if
+// the request reached this point without one.
+func SessionFromContext(ctx context.Context) *Session {
+	sess, _ := ctx.Value(sessionContextKey{}).(*Session)
+	return sess
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	return xml.NewEncoder(w).Encode(v)
+}
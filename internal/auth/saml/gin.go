@@ -0,0 +1,31 @@
+package saml
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MountGin registers the metadata, login, ACS, and SLO endpoints on r under
+// "/saml/*" and returns a RequireAuth middleware that protects routes
+// mounted elsewhere on r.
+func MountGin(r *gin.Engine, opts Options) (requireAuth gin.HandlerFunc, err error) {
+	sp, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	r.GET("/saml/metadata", gin.WrapF(sp.metadataHandler))
+	r.GET("/saml/login", gin.WrapF(sp.loginHandler))
+	r.POST("/saml/acs", gin.WrapF(sp.acsHandler))
+	r.GET("/saml/slo", gin.WrapF(sp.sloHandler))
+
+	middleware := func(c *gin.Context) {
+		sp.requireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			c.Request = r
+			c.Next()
+		})).ServeHTTP(c.Writer, c.Request)
+	}
+
+	return middleware, nil
+}
@@ -0,0 +1,80 @@
+// Package saml implements a SAML 2.0 Service Provider usable from either
+// the gin or chi router: metadata, ACS, and SLO endpoints, plus a
+// RequireAuth middleware that redirects unauthenticated requests to the
+// IdP and a JWT-backed session cookie.
+package saml
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"net/url"
+	"time"
+
+	"github.com/crewjam/saml"
+)
+
+// Options configures the SAML SP mounted by Mount or MountGin.
+type Options struct {
+	// EntityID is this SP's entity ID, advertised in its metadata.
+	EntityID string
+	// RootURL is the externally reachable base URL the SP is served at;
+	// the metadata, ACS, and SLO endpoint URLs are derived from it.
+	RootURL *url.URL
+
+	// IDPMetadata describes the identity provider to federate with.
+	IDPMetadata *saml.EntityDescriptor
+
+	// Key and Certificate are used to sign authentication requests and
+	// decrypt/verify assertions.
+	Key         *rsa.PrivateKey
+	Certificate *x509.Certificate
+
+	// AttributeMap maps SAML assertion attribute names to session claim
+	// names, e.g. {"email": "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress"}.
+	AttributeMap map[string]string
+
+	// SessionSigningKey signs the JWT issued after a successful login.
+	SessionSigningKey []byte
+	// SessionTTL bounds how long the session JWT is valid for.
+	SessionTTL time.Duration
+	// CookieName is the httpOnly cookie the session JWT is stored in.
+	CookieName string
+	// CookieDomain scopes the session cookie; empty means host-only.
+	CookieDomain string
+
+	// Store tracks consumed assertion IDs to reject replayed assertions.
+	Store Store
+
+	// RelayState is the default path to redirect to after a successful
+	// login when the original request didn't carry its own RelayState.
+	RelayState string
+}
+
+func (o Options) cookieName() string {
+	if o.CookieName != "" {
+		return o.CookieName
+	}
+	return "sp_session"
+}
+
+// requestCookieName is the cookie used to carry the outstanding
+// AuthnRequest ID between loginHandler and acsHandler.
+func (o Options) requestCookieName() string {
+	return o.cookieName() + "_req"
+}
+
+func (o Options) metadataURL() *url.URL {
+	return o.RootURL.ResolveReference(&url.URL{Path: "saml/metadata"})
+}
+
+func (o Options) acsURL() *url.URL {
+	return o.RootURL.ResolveReference(&url.URL{Path: "saml/acs"})
+}
+
+func (o Options) sloURL() *url.URL {
+	return o.RootURL.ResolveReference(&url.URL{Path: "saml/slo"})
+}
+
+func (o Options) loginURL() *url.URL {
+	return o.RootURL.ResolveReference(&url.URL{Path: "saml/login"})
+}
@@ -0,0 +1,156 @@
+package saml
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// authnRequestTTL bounds how long an outstanding AuthnRequest ID is
+// accepted for; the IdP round trip is expected to complete well within it.
+const authnRequestTTL = 5 * time.Minute
+
+// Session is the set of claims persisted in the session cookie after a
+// successful SAML login.
+type Session struct {
+	Subject string            `json:"sub"`
+	Claims  map[string]string `json:"claims,omitempty"`
+}
+
+type sessionClaims struct {
+	Claims map[string]string `json:"claims,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type authnRequestClaims struct {
+	jwt.RegisteredClaims
+}
+
+func issueSessionCookie(w http.ResponseWriter, opts Options, sess Session) error {
+	now := time.Now()
+	claims := sessionClaims{
+		Claims: sess.Claims,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   sess.Subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(opts.SessionTTL)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(opts.SessionSigningKey)
+	if err != nil {
+		return fmt.Errorf("saml: sign session token: %w", err)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     opts.cookieName(),
+		Value:    token,
+		Domain:   opts.CookieDomain,
+		Path:     "/",
+		Expires:  now.Add(opts.SessionTTL),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+func sessionFromRequest(r *http.Request, opts Options) (*Session, error) {
+	cookie, err := r.Cookie(opts.cookieName())
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &sessionClaims{}
+	_, err = jwt.ParseWithClaims(cookie.Value, claims, func(t *jwt.Token) (interface{}, error) {
+		return opts.SessionSigningKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, fmt.Errorf("saml: parse session token: %w", err)
+	}
+
+	return &Session{Subject: claims.Subject, Claims: claims.Claims}, nil
+}
+
+func clearSessionCookie(w http.ResponseWriter, opts Options) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     opts.cookieName(),
+		Value:    "",
+		Domain:   opts.CookieDomain,
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// issueAuthnRequestCookie records id, the AuthnRequest ID sent to the IdP,
+// in a short-lived signed cookie so acsHandler can later recover it as the
+// one acceptable InResponseTo value.
+func issueAuthnRequestCookie(w http.ResponseWriter, opts Options, id string) error {
+	now := time.Now()
+	claims := authnRequestClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(authnRequestTTL)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(opts.SessionSigningKey)
+	if err != nil {
+		return fmt.Errorf("saml: sign authn request token: %w", err)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     opts.requestCookieName(),
+		Value:    token,
+		Domain:   opts.CookieDomain,
+		Path:     "/",
+		Expires:  now.Add(authnRequestTTL),
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+// authnRequestIDFromRequest recovers the AuthnRequest ID previously issued
+// by issueAuthnRequestCookie, verifying it hasn't expired or been tampered
+// with.
+func authnRequestIDFromRequest(r *http.Request, opts Options) (string, error) {
+	cookie, err := r.Cookie(opts.requestCookieName())
+	if err != nil {
+		return "", err
+	}
+
+	claims := &authnRequestClaims{}
+	_, err = jwt.ParseWithClaims(cookie.Value, claims, func(t *jwt.Token) (interface{}, error) {
+		return opts.SessionSigningKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return "", fmt.Errorf("saml: parse authn request token: %w", err)
+	}
+
+	return claims.ID, nil
+}
+
+func clearAuthnRequestCookie(w http.ResponseWriter, opts Options) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     opts.requestCookieName(),
+		Value:    "",
+		Domain:   opts.CookieDomain,
+		Path:     "/",
+		Expires:  time.Unix(0, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,42 @@
+package saml
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testOptions() Options {
+	return Options{
+		SessionSigningKey: []byte("test-signing-key"),
+		SessionTTL:        time.Hour,
+	}
+}
+
+func TestSessionCookieRoundTrip(t *testing.T) {
+	opts := testOptions()
+	w := httptest.NewRecorder()
+
+	err := issueSessionCookie(w, opts, Session{Subject: "alice", Claims: map[string]string{"email": "alice@example.com"}})
+	require.NoError(t, err)
+
+	resp := w.Result()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	for _, c := range resp.Cookies() {
+		req.AddCookie(c)
+	}
+
+	sess, err := sessionFromRequest(req, opts)
+	require.NoError(t, err)
+	require.Equal(t, "alice", sess.Subject)
+	require.Equal(t, "alice@example.com", sess.Claims["email"])
+}
+
+func TestSessionFromRequestMissingCookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	_, err := sessionFromRequest(req, testOptions())
+	require.Error(t, err)
+}
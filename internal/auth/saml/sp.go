@@ -0,0 +1,175 @@
+package saml
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/crewjam/saml"
+)
+
+// SP is a SAML service provider exposing metadata, ACS, and SLO endpoints
+// and a RequireAuth middleware, framework-agnostic via net/http and mountable
+// into chi or gin through Mount / MountGin.
+type SP struct {
+	opts Options
+	sp   saml.ServiceProvider
+}
+
+// New builds an SP from opts, wrapping crewjam/saml's ServiceProvider with
+// the endpoint layout and session handling described by opts.
+func New(opts Options) (*SP, error) {
+	if opts.Store == nil {
+		return nil, fmt.Errorf("saml: Options.Store is required for replay protection")
+	}
+	if len(opts.SessionSigningKey) == 0 {
+		return nil, fmt.Errorf("saml: Options.SessionSigningKey is required")
+	}
+
+	return &SP{
+		opts: opts,
+		sp: saml.ServiceProvider{
+			EntityID:    opts.EntityID,
+			Key:         opts.Key,
+			Certificate: opts.Certificate,
+			MetadataURL: *opts.metadataURL(),
+			AcsURL:      *opts.acsURL(),
+			SloURL:      *opts.sloURL(),
+			IDPMetadata: opts.IDPMetadata,
+		},
+	}, nil
+}
+
+func (s *SP) metadataHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	metadata := s.sp.Metadata()
+	if err := writeXML(w, metadata); err != nil {
+		http.Error(w, "failed to render metadata", http.StatusInternalServerError)
+	}
+}
+
+func (s *SP) loginHandler(w http.ResponseWriter, r *http.Request) {
+	relayState := r.URL.Query().Get("RelayState")
+	if relayState == "" {
+		relayState = s.opts.RelayState
+	}
+
+	authReq, err := s.sp.MakeAuthenticationRequest(s.sp.GetSSOBindingLocation(saml.HTTPRedirectBinding), saml.HTTPRedirectBinding, saml.HTTPPostBinding)
+	if err != nil {
+		http.Error(w, "failed to build authentication request", http.StatusInternalServerError)
+		return
+	}
+
+	redirectURL, err := authReq.Redirect(relayState, &s.sp)
+	if err != nil {
+		http.Error(w, "failed to build redirect", http.StatusInternalServerError)
+		return
+	}
+
+	if err := issueAuthnRequestCookie(w, s.opts, authReq.ID); err != nil {
+		http.Error(w, "failed to track authentication request", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, redirectURL.String(), http.StatusFound)
+}
+
+func (s *SP) acsHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	var possibleRequestIDs []string
+	if requestID, err := authnRequestIDFromRequest(r, s.opts); err == nil {
+		possibleRequestIDs = []string{requestID}
+	}
+
+	assertion, err := s.sp.ParseResponse(r, possibleRequestIDs)
+	clearAuthnRequestCookie(w, s.opts)
+	if err != nil {
+		http.Error(w, "invalid SAML response", http.StatusForbidden)
+		return
+	}
+
+	if len(assertion.AuthnStatements) == 0 {
+		http.Error(w, "assertion missing authn statement", http.StatusForbidden)
+		return
+	}
+
+	expiresAt := assertion.Conditions.NotOnOrAfter
+	replayed, err := s.opts.Store.Consume(r.Context(), assertion.ID, expiresAt)
+	if err != nil {
+		http.Error(w, "replay check failed", http.StatusInternalServerError)
+		return
+	}
+	if replayed {
+		http.Error(w, "assertion already used", http.StatusForbidden)
+		return
+	}
+
+	sess := Session{
+		Subject: assertion.Subject.NameID.Value,
+		Claims:  mapAttributes(assertion, s.opts.AttributeMap),
+	}
+
+	if err := issueSessionCookie(w, s.opts, sess); err != nil {
+		http.Error(w, "failed to issue session", http.StatusInternalServerError)
+		return
+	}
+
+	relayState := r.Form.Get("RelayState")
+	if relayState == "" {
+		relayState = s.opts.RelayState
+	}
+	if relayState == "" {
+		relayState = "/"
+	}
+
+	http.Redirect(w, r, relayState, http.StatusFound)
+}
+
+func (s *SP) sloHandler(w http.ResponseWriter, r *http.Request) {
+	clearSessionCookie(w, s.opts)
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// requireAuth checks for a valid session cookie, calling next if present
+// and redirecting to the login endpoint otherwise.
+func (s *SP) requireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sess, err := sessionFromRequest(r, s.opts)
+		if err != nil {
+			loginURL := *s.opts.loginURL()
+			loginURL.RawQuery = url.Values{"RelayState": {r.URL.RequestURI()}}.Encode()
+			http.Redirect(w, r, loginURL.String(), http.StatusFound)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(withSession(r.Context(), sess)))
+	})
+}
+
+func mapAttributes(assertion *saml.Assertion, attributeMap map[string]string) map[string]string {
+	values := map[string]string{}
+	for _, stmt := range assertion.AttributeStatements {
+		for _, attr := range stmt.Attributes {
+			if len(attr.Values) == 0 {
+				continue
+			}
+			values[attr.Name] = attr.Values[0].Value
+		}
+	}
+
+	if attributeMap == nil {
+		return values
+	}
+
+	claims := map[string]string{}
+	for claim, attrName := range attributeMap {
+		if v, ok := values[attrName]; ok {
+			claims[claim] = v
+		}
+	}
+	return claims
+}
@@ -0,0 +1,49 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct{}
+
+func (fakeStore) Consume(context.Context, string, time.Time) (bool, error) { return false, nil }
+
+func TestRequireAuthRedirectsUnderMountPrefix(t *testing.T) {
+	root, err := url.Parse("https://idp.example.com/app/")
+	require.NoError(t, err)
+
+	sp, err := New(Options{
+		RootURL:           root,
+		Store:             fakeStore{},
+		SessionSigningKey: []byte("test-signing-key"),
+		SessionTTL:        time.Hour,
+	})
+	require.NoError(t, err)
+
+	requireAuth := sp.requireAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler should not run for an unauthenticated request")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/app/dashboard?tab=billing&x=1", nil)
+	w := httptest.NewRecorder()
+	requireAuth.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusFound, w.Code)
+	loc, err := url.Parse(w.Header().Get("Location"))
+	require.NoError(t, err)
+
+	// The redirect must land on the login endpoint resolved against
+	// RootURL (mirroring metadataURL/acsURL/sloURL), not a hardcoded
+	// "/saml/login" that would 404 once the SP is mounted under a prefix.
+	require.Equal(t, "https", loc.Scheme)
+	require.Equal(t, "idp.example.com", loc.Host)
+	require.Equal(t, "/app/saml/login", loc.Path)
+	require.Equal(t, "/app/dashboard?tab=billing&x=1", loc.Query().Get("RelayState"))
+}
@@ -0,0 +1,51 @@
+package saml
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store protects against replayed assertions by remembering consumed
+// AssertionIDs until their assertion's NotOnOrAfter has passed.
+type Store interface {
+	// Consume records assertionID as used and reports whether it was
+	// already consumed (i.e. this is a replay). expiresAt should be set
+	// to the assertion's NotOnOrAfter so the record can be evicted once
+	// the assertion itself would no longer be valid.
+	Consume(ctx context.Context, assertionID string, expiresAt time.Time) (replayed bool, err error)
+}
+
+// RedisStore is a Store backed by Redis: each AssertionID becomes a key
+// that expires at the assertion's NotOnOrAfter.
+type RedisStore struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisStore builds a RedisStore. Keys are stored as "<prefix><assertionID>".
+func NewRedisStore(client *redis.Client, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = "saml:assertion:"
+	}
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Consume implements Store using SETNX semantics: the first caller to
+// record an AssertionID gets replayed=false, every subsequent one gets
+// replayed=true until the key expires.
+func (s *RedisStore) Consume(ctx context.Context, assertionID string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return false, fmt.Errorf("saml: assertion %s already expired at %s", assertionID, expiresAt)
+	}
+
+	ok, err := s.client.SetNX(ctx, s.prefix+assertionID, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("saml: record assertion %s: %w", assertionID, err)
+	}
+
+	return !ok, nil
+}
@@ -0,0 +1,103 @@
+package authz
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEnforcer(t *testing.T) *casbin.SyncedEnforcer {
+	t.Helper()
+
+	m, err := LoadRESTfulModel()
+	require.NoError(t, err)
+
+	e, err := casbin.NewSyncedEnforcer(m)
+	require.NoError(t, err)
+
+	// admin can do anything under /admin/*; alice inherits admin via role.
+	_, err = e.AddPolicy("admin", "/admin/*", "GET")
+	require.NoError(t, err)
+	_, err = e.AddPolicy("editor", "/posts/*", "POST")
+	require.NoError(t, err)
+	_, err = e.AddGroupingPolicy("alice", "editor")
+	require.NoError(t, err)
+	_, err = e.AddGroupingPolicy("bob", "admin")
+	require.NoError(t, err)
+
+	return e
+}
+
+func TestGinMiddleware(t *testing.T) {
+	e := newTestEnforcer(t)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(GinMiddleware(e, func(c *gin.Context) string {
+		return c.GetHeader("X-User")
+	}))
+	r.GET("/admin/users", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.POST("/posts/1", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	cases := []struct {
+		name   string
+		user   string
+		method string
+		path   string
+		want   int
+	}{
+		{"role inheritance grants admin wildcard", "bob", http.MethodGet, "/admin/users", http.StatusOK},
+		{"direct policy grants editor wildcard", "alice", http.MethodPost, "/posts/1", http.StatusOK},
+		{"no policy denies", "alice", http.MethodGet, "/admin/users", http.StatusForbidden},
+		{"unknown subject denies", "mallory", http.MethodGet, "/admin/users", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("X-User", tc.user)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, tc.want, w.Code)
+		})
+	}
+}
+
+func TestChiMiddleware(t *testing.T) {
+	e := newTestEnforcer(t)
+
+	r := chi.NewRouter()
+	r.Use(ChiMiddleware(e, func(req *http.Request) string {
+		return req.Header.Get("X-User")
+	}))
+	r.Get("/admin/users", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	r.Post("/posts/1", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	cases := []struct {
+		name   string
+		user   string
+		method string
+		path   string
+		want   int
+	}{
+		{"role inheritance grants admin wildcard", "bob", http.MethodGet, "/admin/users", http.StatusOK},
+		{"direct policy grants editor wildcard", "alice", http.MethodPost, "/posts/1", http.StatusOK},
+		{"no policy denies", "alice", http.MethodGet, "/admin/users", http.StatusForbidden},
+		{"unknown subject denies", "mallory", http.MethodGet, "/admin/users", http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set("X-User", tc.user)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			require.Equal(t, tc.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,34 @@
+// Package authz provides Casbin-based RBAC/ABAC authorization that can be
+// wired into either the gin or chi router, with policies stored on disk or
+// in the application's GORM database.
+package authz
+
+import (
+	"fmt"
+
+	"github.com/casbin/casbin/v2"
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"gorm.io/gorm"
+)
+
+// NewEnforcer builds a casbin.SyncedEnforcer from m, which is either a model
+// file path or a parsed model.Model such as one returned by
+// LoadRESTfulModel / LoadABACModel. When db is nil, policy is loaded from
+// and saved to the policy file at the given path. When db is non-nil,
+// policy is instead persisted through a GORM adapter backed by db and the
+// policy argument is ignored.
+//
+// SyncedEnforcer guards Enforce and LoadPolicy with an internal RWMutex, so
+// the enforcer returned here is safe to share between GinMiddleware /
+// ChiMiddleware (reading via Enforce) and AttachWatcher's reload callback
+// (writing via LoadPolicy) running concurrently.
+func NewEnforcer(m interface{}, policy string, db *gorm.DB) (*casbin.SyncedEnforcer, error) {
+	if db != nil {
+		adapter, err := gormadapter.NewAdapterByDB(db)
+		if err != nil {
+			return nil, fmt.Errorf("authz: create gorm adapter: %w", err)
+		}
+		return casbin.NewSyncedEnforcer(m, adapter)
+	}
+	return casbin.NewSyncedEnforcer(m, policy)
+}
@@ -0,0 +1,56 @@
+package authz
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestNewEnforcerFilePolicy(t *testing.T) {
+	dir := t.TempDir()
+	modelPath := filepath.Join(dir, "model.conf")
+	require.NoError(t, os.WriteFile(modelPath, []byte(RESTfulModel), 0o644))
+	policy := filepath.Join(dir, "policy.csv")
+	require.NoError(t, os.WriteFile(policy, []byte("p, admin, /admin/*, GET\n"), 0o644))
+
+	// File-backed policy pairs a model file path with a policy file path,
+	// the same two-string form casbin.NewEnforcer itself expects.
+	e, err := NewEnforcer(modelPath, policy, nil)
+	require.NoError(t, err)
+
+	ok, err := e.Enforce("admin", "/admin/users", "GET")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestNewEnforcerGORMAdapter(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// LoadABACModel's model.Model composes with the GORM adapter path,
+	// which is the gap this test guards against.
+	m, err := LoadABACModel()
+	require.NoError(t, err)
+
+	e, err := NewEnforcer(m, "", db)
+	require.NoError(t, err)
+
+	_, err = e.AddPolicy(`r.sub == "alice"`, "/posts/1", "POST")
+	require.NoError(t, err)
+
+	ok, err := e.Enforce("alice", "/posts/1", "POST")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Policy persisted through the GORM adapter survives a fresh enforcer
+	// built against the same db.
+	e2, err := NewEnforcer(m, "", db)
+	require.NoError(t, err)
+	ok, err = e2.Enforce("alice", "/posts/1", "POST")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
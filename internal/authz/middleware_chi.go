@@ -0,0 +1,28 @@
+package authz
+
+import (
+	"net/http"
+
+	"github.com/casbin/casbin/v2"
+)
+
+// ChiMiddleware returns chi middleware that authorizes each request against
+// e, using subjectFn to derive the acting subject, the request path as the
+// object, and the HTTP method as the action.
+func ChiMiddleware(e *casbin.SyncedEnforcer, subjectFn func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sub := subjectFn(r)
+			ok, err := e.Enforce(sub, r.URL.Path, r.Method)
+			if err != nil {
+				http.Error(w, "authorization check failed", http.StatusInternalServerError)
+				return
+			}
+			if !ok {
+				http.Error(w, "forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
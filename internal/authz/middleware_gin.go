@@ -0,0 +1,28 @@
+package authz
+
+import (
+	"net/http"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware returns a gin.HandlerFunc that authorizes each request
+// against e, using subjectFn to derive the acting subject, the request path
+// as the object, and the HTTP method as the action. Denied or failed checks
+// abort the chain with 403 or 500 respectively.
+func GinMiddleware(e *casbin.SyncedEnforcer, subjectFn func(*gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sub := subjectFn(c)
+		ok, err := e.Enforce(sub, c.Request.URL.Path, c.Request.Method)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "authorization check failed"})
+			return
+		}
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,50 @@
+package authz
+
+import "github.com/casbin/casbin/v2/model"
+
+// RESTfulModel is the default RBAC model for path/method based REST APIs:
+// subjects inherit permissions through roles, objects are matched with
+// keyMatch2 so that path parameters (e.g. "/users/:id") work, and actions
+// are HTTP methods.
+const RESTfulModel = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && r.act == p.act
+`
+
+// ABACModel is the default attribute-based model, where policies are boolean
+// expressions evaluated against request attributes instead of fixed roles.
+const ABACModel = `
+[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub_rule, obj, act
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = eval(p.sub_rule) && r.obj == p.obj && r.act == p.act
+`
+
+// LoadRESTfulModel parses the built-in RESTful model definition.
+func LoadRESTfulModel() (model.Model, error) {
+	return model.NewModelFromString(RESTfulModel)
+}
+
+// LoadABACModel parses the built-in ABAC model definition.
+func LoadABACModel() (model.Model, error) {
+	return model.NewModelFromString(ABACModel)
+}
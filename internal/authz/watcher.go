@@ -0,0 +1,123 @@
+package authz
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultWatcherChannel = "casbin-policy-updates"
+
+// RedisWatcher implements casbin's persist.Watcher on top of Redis pub/sub:
+// Update publishes a notification on channel, and every instance subscribed
+// to the same channel invokes its registered callback so all enforcers
+// reload policy together, regardless of which instance made the change.
+type RedisWatcher struct {
+	client  *redis.Client
+	channel string
+	selfID  string
+	cancel  context.CancelFunc
+
+	mu sync.RWMutex
+	fn func(string)
+}
+
+// NewRedisWatcher starts watching channel (defaultWatcherChannel when
+// empty) for policy-update notifications published by any instance,
+// including this one.
+func NewRedisWatcher(client *redis.Client, channel string) (*RedisWatcher, error) {
+	if channel == "" {
+		channel = defaultWatcherChannel
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("authz: generate watcher id: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &RedisWatcher{
+		client:  client,
+		channel: channel,
+		selfID:  hex.EncodeToString(id),
+		cancel:  cancel,
+	}
+
+	sub := client.Subscribe(ctx, channel)
+	go w.loop(ctx, sub)
+
+	return w, nil
+}
+
+func (w *RedisWatcher) loop(ctx context.Context, sub *redis.PubSub) {
+	defer sub.Close()
+	ch := sub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+			if msg.Payload == w.selfID {
+				continue
+			}
+			w.mu.RLock()
+			fn := w.fn
+			w.mu.RUnlock()
+			if fn != nil {
+				fn(msg.Payload)
+			}
+		}
+	}
+}
+
+// SetUpdateCallback implements persist.Watcher.
+func (w *RedisWatcher) SetUpdateCallback(fn func(string)) error {
+	w.mu.Lock()
+	w.fn = fn
+	w.mu.Unlock()
+	return nil
+}
+
+// Update implements persist.Watcher by publishing this instance's ID so
+// peers reload while this instance ignores its own notification.
+func (w *RedisWatcher) Update() error {
+	return w.client.Publish(context.Background(), w.channel, w.selfID).Err()
+}
+
+// Close stops the subscription loop.
+func (w *RedisWatcher) Close() {
+	w.cancel()
+}
+
+var _ persist.Watcher = (*RedisWatcher)(nil)
+
+// AttachWatcher wires w into e so that notifications from peers trigger a
+// policy reload. e must be a *casbin.SyncedEnforcer (as returned by
+// NewEnforcer) so the reload's LoadPolicy doesn't race with concurrent
+// Enforce calls from GinMiddleware/ChiMiddleware.
+func AttachWatcher(e *casbin.SyncedEnforcer, w *RedisWatcher) error {
+	// e.SetWatcher registers its own default callback on w, one that
+	// reloads through the embedded, unsynchronized *casbin.Enforcer
+	// rather than SyncedEnforcer's locking override. Register it first,
+	// then install our own callback afterward so it's the one that
+	// sticks, calling e.LoadPolicy() (the synced, RWMutex-guarded
+	// version) instead.
+	if err := e.SetWatcher(w); err != nil {
+		return err
+	}
+	return w.SetUpdateCallback(func(string) {
+		if err := e.LoadPolicy(); err != nil {
+			log.Printf("authz: reload policy after watcher notification: %v", err)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package authz
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRedisWatcherReloadsPeerOnUpdate(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	publisher := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer publisher.Close()
+	subscriber := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer subscriber.Close()
+
+	pw, err := NewRedisWatcher(publisher, "")
+	require.NoError(t, err)
+	defer pw.Close()
+	sw, err := NewRedisWatcher(subscriber, "")
+	require.NoError(t, err)
+	defer sw.Close()
+
+	reloaded := make(chan struct{}, 1)
+	require.NoError(t, sw.SetUpdateCallback(func(string) {
+		reloaded <- struct{}{}
+	}))
+
+	// Give the subscribe loop time to establish before publishing.
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, pw.Update())
+
+	select {
+	case <-reloaded:
+	case <-time.After(time.Second):
+		t.Fatal("peer watcher was not notified of the update")
+	}
+}
+
+func TestRedisWatcherIgnoresOwnUpdate(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: s.Addr()})
+	defer client.Close()
+
+	w, err := NewRedisWatcher(client, "")
+	require.NoError(t, err)
+	defer w.Close()
+
+	reloaded := make(chan struct{}, 1)
+	require.NoError(t, w.SetUpdateCallback(func(string) {
+		reloaded <- struct{}{}
+	}))
+
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, w.Update())
+
+	select {
+	case <-reloaded:
+		t.Fatal("watcher should not reload on its own update")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestAttachWatcherReloadsEnforcerPolicy(t *testing.T) {
+	rs, err := miniredis.Run()
+	require.NoError(t, err)
+	defer rs.Close()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	m, err := LoadRESTfulModel()
+	require.NoError(t, err)
+
+	// writer and reader are two enforcer instances sharing the same GORM
+	// policy store, modeling two application replicas.
+	writer, err := NewEnforcer(m, "", db)
+	require.NoError(t, err)
+	reader, err := NewEnforcer(m, "", db)
+	require.NoError(t, err)
+
+	readerWatcher, err := NewRedisWatcher(redis.NewClient(&redis.Options{Addr: rs.Addr()}), "")
+	require.NoError(t, err)
+	defer readerWatcher.Close()
+	require.NoError(t, AttachWatcher(reader, readerWatcher))
+
+	writerWatcher, err := NewRedisWatcher(redis.NewClient(&redis.Options{Addr: rs.Addr()}), "")
+	require.NoError(t, err)
+	defer writerWatcher.Close()
+	require.NoError(t, AttachWatcher(writer, writerWatcher))
+
+	ok, err := reader.Enforce("admin", "/admin/users", "GET")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Hammer reader.Enforce concurrently with the reload below: under
+	// -race this reproduces the plain *casbin.Enforcer data race between
+	// Enforce and the watcher callback's LoadPolicy (reader must be a
+	// *casbin.SyncedEnforcer, reached through AttachWatcher, for this to
+	// stay race-free).
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_, _ = reader.Enforce("admin", "/admin/users", "GET")
+			}
+		}
+	}()
+
+	// writer adds a policy directly in the shared store and notifies peers
+	// the way a real instance would after SavePolicy/AddPolicy.
+	_, err = writer.AddPolicy("admin", "/admin/*", "GET")
+	require.NoError(t, err)
+	require.NoError(t, writerWatcher.Update())
+
+	require.Eventually(t, func() bool {
+		ok, err := reader.Enforce("admin", "/admin/users", "GET")
+		return err == nil && ok
+	}, time.Second, 10*time.Millisecond, "reader should reload policy after watcher notification")
+
+	close(stop)
+	wg.Wait()
+}
@@ -0,0 +1,50 @@
+package geoip
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP returns the originating client IP for r, honoring X-Forwarded-For
+// and X-Real-IP only when r.RemoteAddr is in trustedProxies — otherwise
+// RemoteAddr itself is returned, since an untrusted proxy's headers can't be
+// relied on.
+func ClientIP(r *http.Request, trustedProxies []*net.IPNet) net.IP {
+	remoteIP := remoteIPOf(r)
+	if remoteIP == nil || !trusted(remoteIP, trustedProxies) {
+		return remoteIP
+	}
+
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		parts := strings.Split(xff, ",")
+		if ip := net.ParseIP(strings.TrimSpace(parts[0])); ip != nil {
+			return ip
+		}
+	}
+
+	if xri := r.Header.Get("X-Real-IP"); xri != "" {
+		if ip := net.ParseIP(strings.TrimSpace(xri)); ip != nil {
+			return ip
+		}
+	}
+
+	return remoteIP
+}
+
+func remoteIPOf(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+func trusted(ip net.IP, trustedProxies []*net.IPNet) bool {
+	for _, network := range trustedProxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package geoip
+
+import "net"
+
+// Config controls how GinMiddleware and ChiMiddleware resolve and expose
+// geo data.
+type Config struct {
+	// Resolver performs the actual lookup; defaults to NoopResolver when nil.
+	Resolver Resolver
+	// TrustedProxies lists the networks allowed to set X-Forwarded-For /
+	// X-Real-IP; requests from any other address use their RemoteAddr.
+	TrustedProxies []*net.IPNet
+	// SetHeaders, when true, also sets X-Geo-Country, X-Geo-Subdivision,
+	// X-Geo-City, and X-Geo-ASN on the request for downstream handlers that
+	// read headers rather than the request context.
+	SetHeaders bool
+}
+
+func (c Config) resolver() Resolver {
+	if c.Resolver != nil {
+		return c.Resolver
+	}
+	return NoopResolver{}
+}
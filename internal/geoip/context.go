@@ -0,0 +1,16 @@
+package geoip
+
+import "context"
+
+type recordContextKey struct{}
+
+func withRecord(ctx context.Context, rec Record) context.Context {
+	return context.WithValue(ctx, recordContextKey{}, rec)
+}
+
+// FromContext returns the Record attached by GinMiddleware or ChiMiddleware,
+// and false if none was attached (e.g. the middleware wasn't installed).
+func FromContext(ctx context.Context) (Record, bool) {
+	rec, ok := ctx.Value(recordContextKey{}).(Record)
+	return rec, ok
+}
@@ -0,0 +1,64 @@
+package geoip
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver struct{ rec Record }
+
+func (f fakeResolver) Lookup(net.IP) (Record, error) { return f.rec, nil }
+
+func mustCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	_, n, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return n
+}
+
+func TestClientIPHonorsTrustedProxy(t *testing.T) {
+	proxies := []*net.IPNet{mustCIDR(t, "10.0.0.0/8")}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.5:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.5")
+
+	ip := ClientIP(req, proxies)
+	require.Equal(t, "203.0.113.7", ip.String())
+}
+
+func TestClientIPIgnoresUntrustedProxy(t *testing.T) {
+	proxies := []*net.IPNet{mustCIDR(t, "10.0.0.0/8")}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "198.51.100.9:1234"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+
+	ip := ClientIP(req, proxies)
+	require.Equal(t, "198.51.100.9", ip.String())
+}
+
+func TestChiMiddlewareAttachesRecord(t *testing.T) {
+	want := Record{Country: "US", City: "San Francisco"}
+
+	r := chi.NewRouter()
+	r.Use(ChiMiddleware(Config{Resolver: fakeResolver{rec: want}, SetHeaders: true}))
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		rec, ok := FromContext(r.Context())
+		require.True(t, ok)
+		require.Equal(t, want, rec)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "US", w.Header().Get("X-Geo-Country"))
+}
@@ -0,0 +1,26 @@
+package geoip
+
+import "net/http"
+
+// ChiMiddleware resolves the client's geo Record according to cfg and
+// attaches it to the request context, optionally also setting X-Geo-*
+// response headers for downstream consumers.
+func ChiMiddleware(cfg Config) func(http.Handler) http.Handler {
+	resolver := cfg.resolver()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := ClientIP(r, cfg.TrustedProxies)
+
+			rec, err := resolver.Lookup(ip)
+			if err == nil {
+				r = r.WithContext(withRecord(r.Context(), rec))
+				if cfg.SetHeaders {
+					setHeaders(w.Header(), rec)
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
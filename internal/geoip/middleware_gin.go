@@ -0,0 +1,35 @@
+package geoip
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware resolves the client's geo Record according to cfg and
+// attaches it to the request context, optionally also setting X-Geo-*
+// response headers for downstream consumers.
+func GinMiddleware(cfg Config) gin.HandlerFunc {
+	resolver := cfg.resolver()
+
+	return func(c *gin.Context) {
+		ip := ClientIP(c.Request, cfg.TrustedProxies)
+
+		rec, err := resolver.Lookup(ip)
+		if err == nil {
+			c.Request = c.Request.WithContext(withRecord(c.Request.Context(), rec))
+			if cfg.SetHeaders {
+				setHeaders(c.Writer.Header(), rec)
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func setHeaders(h interface{ Set(string, string) }, rec Record) {
+	h.Set("X-Geo-Country", rec.Country)
+	h.Set("X-Geo-Subdivision", rec.Subdivision)
+	h.Set("X-Geo-City", rec.City)
+	h.Set("X-Geo-ASN", strconv.FormatUint(uint64(rec.ASN), 10))
+}
@@ -0,0 +1,127 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/oschwald/geoip2-golang"
+)
+
+// MMDBResolver resolves Records from a MaxMind GeoIP2 City+ISP database,
+// reloading it automatically whenever the file on disk changes.
+type MMDBResolver struct {
+	path string
+
+	mu      sync.RWMutex
+	reader  *geoip2.Reader
+	watcher *fsnotify.Watcher
+}
+
+// NewMMDBResolver opens the mmdb file at path and starts watching it for
+// changes so updates (e.g. a periodic database refresh) take effect without
+// a restart.
+func NewMMDBResolver(path string) (*MMDBResolver, error) {
+	reader, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("geoip: open %s: %w", path, err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		reader.Close()
+		return nil, fmt.Errorf("geoip: create watcher: %w", err)
+	}
+	if err := watcher.Add(path); err != nil {
+		reader.Close()
+		watcher.Close()
+		return nil, fmt.Errorf("geoip: watch %s: %w", path, err)
+	}
+
+	r := &MMDBResolver{path: path, reader: reader, watcher: watcher}
+	go r.watch()
+
+	return r, nil
+}
+
+func (r *MMDBResolver) watch() {
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				r.reload()
+			}
+		case _, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
+func (r *MMDBResolver) reload() {
+	reader, err := geoip2.Open(r.path)
+	if err != nil {
+		// Keep serving the previous database; a write in progress or a
+		// transient I/O error shouldn't take GeoIP lookups offline.
+		return
+	}
+
+	r.mu.Lock()
+	old := r.reader
+	r.reader = reader
+	r.mu.Unlock()
+
+	old.Close()
+}
+
+// Lookup implements Resolver.
+func (r *MMDBResolver) Lookup(ip net.IP) (Record, error) {
+	// Held for the whole lookup, not just the pointer read: reload closes
+	// the previous reader (which munmaps its backing buffer) only after
+	// acquiring the write lock, so releasing this early would let a
+	// concurrent reload unmap memory still being read below.
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reader := r.reader
+
+	city, err := reader.City(ip)
+	if err != nil {
+		return Record{}, fmt.Errorf("geoip: lookup %s: %w", ip, err)
+	}
+
+	asn, err := reader.ASN(ip)
+	if err != nil {
+		// Not every mmdb build includes ASN data; degrade gracefully.
+		asn = nil
+	}
+
+	rec := Record{
+		Country:   city.Country.IsoCode,
+		City:      city.City.Names["en"],
+		Latitude:  city.Location.Latitude,
+		Longitude: city.Location.Longitude,
+	}
+	if len(city.Subdivisions) > 0 {
+		rec.Subdivision = city.Subdivisions[0].IsoCode
+	}
+	if asn != nil {
+		rec.ASN = asn.AutonomousSystemNumber
+	}
+
+	return rec, nil
+}
+
+// Close stops watching for file changes and releases the underlying
+// database handle.
+func (r *MMDBResolver) Close() error {
+	r.watcher.Close()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.reader.Close()
+}
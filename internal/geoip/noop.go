@@ -0,0 +1,12 @@
+package geoip
+
+import "net"
+
+// NoopResolver always returns an empty Record and is used as a fallback
+// when no mmdb file is configured, e.g. in tests.
+type NoopResolver struct{}
+
+// Lookup implements Resolver.
+func (NoopResolver) Lookup(net.IP) (Record, error) {
+	return Record{}, nil
+}
@@ -0,0 +1,22 @@
+// Package geoip enriches incoming requests with MaxMind GeoIP2 data (country,
+// subdivision, city, ASN, coordinates), resolved from a trusted client IP and
+// attached to the request context for downstream handlers — including geo-
+// based rate limiting, audit logging, and access rules alongside authz.
+package geoip
+
+import "net"
+
+// Record is the geo data resolved for a single IP address.
+type Record struct {
+	Country     string
+	Subdivision string
+	City        string
+	ASN         uint
+	Latitude    float64
+	Longitude   float64
+}
+
+// Resolver looks up geo data for an IP address.
+type Resolver interface {
+	Lookup(ip net.IP) (Record, error)
+}
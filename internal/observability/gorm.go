@@ -0,0 +1,77 @@
+package observability
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const startTimeKey = "observability:start_time"
+
+// InstrumentGORM registers before/after callbacks on db's create, query,
+// update, delete, row, and raw operations that record
+// db_query_duration_seconds labeled by the statement's model and operation.
+func InstrumentGORM(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("observability:before_create", beforeQuery); err != nil {
+		return err
+	}
+	if err := db.Callback().Create().After("gorm:create").Register("observability:after_create", afterQuery("create")); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("observability:before_query", beforeQuery); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().After("gorm:query").Register("observability:after_query", afterQuery("query")); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("observability:before_update", beforeQuery); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("observability:after_update", afterQuery("update")); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("observability:before_delete", beforeQuery); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().After("gorm:delete").Register("observability:after_delete", afterQuery("delete")); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().Before("gorm:row").Register("observability:before_row", beforeQuery); err != nil {
+		return err
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("observability:after_row", afterQuery("row")); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().Before("gorm:raw").Register("observability:before_raw", beforeQuery); err != nil {
+		return err
+	}
+	if err := db.Callback().Raw().After("gorm:raw").Register("observability:after_raw", afterQuery("raw")); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func beforeQuery(db *gorm.DB) {
+	db.InstanceSet(startTimeKey, time.Now())
+}
+
+func afterQuery(operation string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(startTimeKey)
+		if !ok {
+			return
+		}
+		start, ok := v.(time.Time)
+		if !ok {
+			return
+		}
+
+		model := "unknown"
+		if db.Statement.Schema != nil {
+			model = db.Statement.Schema.Name
+		}
+
+		dbQueryDuration.WithLabelValues(model, operation).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,13 @@
+package observability
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler returns the HTTP handler that serves the registered metrics in the
+// Prometheus exposition format. Mount it at "/metrics".
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
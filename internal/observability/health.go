@@ -0,0 +1,61 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// Probe reports whether a dependency is reachable within timeout.
+type Probe func(ctx context.Context) error
+
+// DBProbe returns a Probe that pings db.
+func DBProbe(db *gorm.DB) Probe {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// RedisProbe returns a Probe that pings client.
+func RedisProbe(client *redis.Client) Probe {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// HealthzHandler always reports liveness; it never depends on downstream
+// services, so a process that's still running but can't reach its
+// dependencies stays "alive" and is only taken out of rotation via readyz.
+func HealthzHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+}
+
+// ReadyzHandler returns a handler that reports 200 only when every probe
+// succeeds within timeout, and 503 otherwise.
+func ReadyzHandler(timeout time.Duration, probes ...Probe) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		for _, probe := range probes {
+			if err := probe(ctx); err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok"))
+	})
+}
@@ -0,0 +1,37 @@
+package observability
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadyzHandler(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	fail := func(ctx context.Context) error { return errors.New("down") }
+
+	t.Run("all probes succeed", func(t *testing.T) {
+		h := ReadyzHandler(time.Second, ok, ok)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+		require.Equal(t, 200, w.Code)
+	})
+
+	t.Run("a failing probe returns 503", func(t *testing.T) {
+		h := ReadyzHandler(time.Second, ok, fail)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("GET", "/readyz", nil))
+		require.Equal(t, 503, w.Code)
+	})
+}
+
+func TestHealthzHandler(t *testing.T) {
+	h := HealthzHandler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+	require.Equal(t, 200, w.Code)
+}
@@ -0,0 +1,34 @@
+// Package observability wires Prometheus RED metrics and health/readiness
+// probes into the gin and chi routers, plus GORM and redis instrumentation
+// hooks so downstream calls show up on the same dashboards.
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests processed, labeled by method, route, and status.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds, labeled by method, route, and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	dbQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "GORM query latency in seconds, labeled by model and operation.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"model", "operation"})
+
+	redisCommandDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "redis_command_duration_seconds",
+		Help:    "Redis command latency in seconds, labeled by command name.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"command"})
+)
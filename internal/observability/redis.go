@@ -0,0 +1,45 @@
+package observability
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisHook implements redis.Hook, recording redis_command_duration_seconds
+// for every command and pipeline executed through the client it's attached
+// to via client.AddHook.
+type RedisHook struct{}
+
+var _ redis.Hook = RedisHook{}
+
+// DialHook implements redis.Hook; connection dialing isn't instrumented.
+func (RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook implements redis.Hook by timing a single command.
+func (RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		redisCommandDuration.WithLabelValues(cmd.Name()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook by timing each command in a
+// pipeline individually, attributing the shared pipeline latency to every
+// command name it contains.
+func (RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		elapsed := time.Since(start).Seconds()
+		for _, cmd := range cmds {
+			redisCommandDuration.WithLabelValues(cmd.Name()).Observe(elapsed)
+		}
+		return err
+	}
+}
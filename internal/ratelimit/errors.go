@@ -0,0 +1,5 @@
+package ratelimit
+
+import "errors"
+
+var errUnexpectedScriptResult = errors.New("ratelimit: unexpected script result shape")
@@ -0,0 +1,15 @@
+package ratelimit
+
+import "strings"
+
+// ComposeKey joins non-empty parts (e.g. client IP, user ID, route) into a
+// single rate-limit bucket identity.
+func ComposeKey(parts ...string) string {
+	nonEmpty := parts[:0]
+	for _, p := range parts {
+		if p != "" {
+			nonEmpty = append(nonEmpty, p)
+		}
+	}
+	return "ratelimit:" + strings.Join(nonEmpty, ":")
+}
@@ -0,0 +1,141 @@
+// Package ratelimit implements a Redis-backed sliding-window log rate
+// limiter with gin and chi middleware adapters, falling back to an
+// in-memory limiter when Redis is unreachable.
+package ratelimit
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Result describes the outcome of a single Allow check.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Limiter is a sliding-window log rate limiter. Key derives the rate-limit
+// bucket identity from the request context (e.g. composing IP, user ID, and
+// route); Limit and Window bound how many requests that key may make per
+// window. When FailOpen is true, requests are allowed if Redis is
+// unreachable instead of being rejected.
+type Limiter struct {
+	Redis    *redis.Client
+	Key      func(ctx context.Context) string
+	Limit    int
+	Window   time.Duration
+	FailOpen bool
+
+	memOnce sync.Once
+	memory  *memoryLimiter
+}
+
+// slidingWindowScript atomically evicts expired entries, checks the current
+// count against the limit, and - if under the limit - records this request,
+// all in one round trip so concurrent requests can't race past the limit.
+// It's loaded once and invoked via EVALSHA (falling back to EVAL on a cache
+// miss) by the redis.Script helper.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+local member = ARGV[4]
+
+redis.call('ZREMRANGEBYSCORE', key, 0, now - window)
+
+local count = redis.call('ZCARD', key)
+if count < limit then
+	redis.call('ZADD', key, now, member)
+	redis.call('PEXPIRE', key, window)
+	return {1, limit - count - 1, 0}
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local retryAfter = 0
+if #oldest == 2 then
+	retryAfter = tonumber(oldest[2]) + window - now
+end
+return {0, 0, retryAfter}
+`)
+
+// Allow reports whether the request identified by key is permitted under
+// the sliding window, falling back to an in-memory limiter if Redis cannot
+// be reached.
+func (l *Limiter) Allow(ctx context.Context) (Result, error) {
+	key := l.Key(ctx)
+
+	if l.Redis != nil {
+		res, err := l.allowRedis(ctx, key)
+		if err == nil {
+			return res, nil
+		}
+		if !l.FailOpen {
+			return Result{}, err
+		}
+		// Redis is unreachable and we're configured to fail open: fall
+		// through to the in-memory limiter rather than blocking traffic.
+	}
+
+	return l.allowMemory(key), nil
+}
+
+func (l *Limiter) allowRedis(ctx context.Context, key string) (Result, error) {
+	now := time.Now().UnixMilli()
+	windowMS := l.Window.Milliseconds()
+
+	member, err := randomMember()
+	if err != nil {
+		return Result{}, err
+	}
+
+	raw, err := slidingWindowScript.Run(ctx, l.Redis, []string{key}, now, windowMS, l.Limit, member).Result()
+	if err != nil {
+		return Result{}, err
+	}
+
+	values, ok := raw.([]interface{})
+	if !ok || len(values) != 3 {
+		return Result{}, errUnexpectedScriptResult
+	}
+
+	allowed := toInt64(values[0]) == 1
+	remaining := int(toInt64(values[1]))
+	retryAfter := time.Duration(toInt64(values[2])) * time.Millisecond
+
+	return Result{
+		Allowed:    allowed,
+		Limit:      l.Limit,
+		Remaining:  remaining,
+		RetryAfter: retryAfter,
+	}, nil
+}
+
+func (l *Limiter) allowMemory(key string) Result {
+	l.memOnce.Do(func() { l.memory = newMemoryLimiter() })
+	return l.memory.allow(key, l.Limit, l.Window)
+}
+
+func randomMember() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func toInt64(v interface{}) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestLimiterAllowMemoryConcurrent guards against the lazy memory field
+// being initialized by more than one goroutine: run under -race, a racy
+// nil-check-then-assign would be flagged, and a lost init would silently
+// reset a key's fallback window mid-burst.
+func TestLimiterAllowMemoryConcurrent(t *testing.T) {
+	l := &Limiter{Limit: 1000, Window: time.Minute}
+
+	var wg sync.WaitGroup
+	results := make([]Result, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = l.allowMemory("shared-key")
+		}(i)
+	}
+	wg.Wait()
+
+	allowed := 0
+	for _, r := range results {
+		if r.Allowed {
+			allowed++
+		}
+	}
+	require.Equal(t, 50, allowed, "every request should be accounted for against the single memory limiter")
+}
@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// memoryLimiter is a process-local sliding-window log limiter used as a
+// fallback when Redis is unreachable. It trades cross-instance accuracy for
+// availability.
+type memoryLimiter struct {
+	mu  sync.Mutex
+	log map[string][]time.Time
+}
+
+func newMemoryLimiter() *memoryLimiter {
+	return &memoryLimiter{log: make(map[string][]time.Time)}
+}
+
+func (m *memoryLimiter) allow(key string, limit int, window time.Duration) Result {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	cutoff := now.Add(-window)
+
+	entries := m.log[key]
+	kept := entries[:0]
+	for _, t := range entries {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) >= limit {
+		retryAfter := kept[0].Add(window).Sub(now)
+		m.log[key] = kept
+		return Result{Allowed: false, Limit: limit, Remaining: 0, RetryAfter: retryAfter}
+	}
+
+	kept = append(kept, now)
+	m.log[key] = kept
+
+	return Result{Allowed: true, Limit: limit, Remaining: limit - len(kept), RetryAfter: 0}
+}
@@ -0,0 +1,33 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiterAllow(t *testing.T) {
+	m := newMemoryLimiter()
+
+	res := m.allow("user:1", 2, time.Minute)
+	require.True(t, res.Allowed)
+	require.Equal(t, 1, res.Remaining)
+
+	res = m.allow("user:1", 2, time.Minute)
+	require.True(t, res.Allowed)
+	require.Equal(t, 0, res.Remaining)
+
+	res = m.allow("user:1", 2, time.Minute)
+	require.False(t, res.Allowed)
+	require.Greater(t, res.RetryAfter, time.Duration(0))
+
+	// A different key has its own independent window.
+	res = m.allow("user:2", 2, time.Minute)
+	require.True(t, res.Allowed)
+}
+
+func TestComposeKey(t *testing.T) {
+	require.Equal(t, "ratelimit:1.2.3.4:42:/posts", ComposeKey("1.2.3.4", "42", "/posts"))
+	require.Equal(t, "ratelimit:1.2.3.4", ComposeKey("1.2.3.4", ""))
+}
@@ -0,0 +1,32 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// ChiMiddleware returns chi middleware that enforces l, setting
+// X-RateLimit-Limit and X-RateLimit-Remaining on every response and
+// Retry-After plus a 429 status on rejected requests.
+func (l *Limiter) ChiMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, err := l.Allow(r.Context())
+			if err != nil {
+				http.Error(w, "rate limit check failed", http.StatusInternalServerError)
+				return
+			}
+
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+
+			if !res.Allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(res.RetryAfter.Seconds())))
+				http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GinMiddleware returns a gin.HandlerFunc that enforces l, setting
+// X-RateLimit-Limit and X-RateLimit-Remaining on every response and
+// Retry-After plus a 429 status on rejected requests.
+func (l *Limiter) GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		res, err := l.Allow(c.Request.Context())
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "rate limit check failed"})
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(res.RetryAfter.Seconds())))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+
+		c.Next()
+	}
+}